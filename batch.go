@@ -0,0 +1,167 @@
+// batch.go
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// maxBatchSize is the largest number of card numbers accepted by a single
+// /validate/batch request.
+const maxBatchSize = 1000
+
+// minCardLength and maxCardLength bound a normalized card number's digit
+// count; entries outside this range are reported as invalid rather than
+// rejecting the whole batch.
+const (
+	minCardLength = 12
+	maxCardLength = 19
+)
+
+// BatchRequest is the body accepted by handleBatchValidate. Entries are
+// intentionally not validated as numeric here: raw input may still contain
+// spaces or dashes that normalizeCardNumber strips before validateOne
+// checks it, so per-entry format checks happen after normalization.
+type BatchRequest struct {
+	Numbers []string `json:"numbers" validate:"required,min=1,max=1000,dive,required"`
+}
+
+// BatchResult is one card's outcome within a batch response.
+type BatchResult struct {
+	Input      string `json:"input"`
+	Normalized string `json:"normalized"`
+	Valid      bool   `json:"valid"`
+	Brand      string `json:"brand,omitempty"`
+	Length     int    `json:"length,omitempty"`
+	CVVLength  int    `json:"cvv_length,omitempty"`
+}
+
+// BatchResponse wraps the per-card results of a batch request, in the same
+// order as the request's Numbers.
+type BatchResponse struct {
+	Results []BatchResult `json:"results"`
+}
+
+// batchWorkerCount returns the configured worker-pool size for batch
+// validation, read from the BATCH_WORKERS env var and defaulting to
+// GOMAXPROCS when unset or invalid.
+func batchWorkerCount() int {
+	if raw := os.Getenv("BATCH_WORKERS"); raw != "" {
+		if n, err := strconv.Atoi(raw); err == nil && n > 0 {
+			return n
+		}
+	}
+	return runtime.GOMAXPROCS(0)
+}
+
+// normalizeCardNumber strips spaces and dashes from a raw card number.
+func normalizeCardNumber(raw string) string {
+	replacer := strings.NewReplacer(" ", "", "-", "")
+	return replacer.Replace(raw)
+}
+
+// validateOne runs the Luhn check and brand classification on a single raw
+// card number, normalizing it first. A normalized number outside
+// [minCardLength, maxCardLength] is reported as invalid rather than
+// rejecting the whole batch.
+func validateOne(raw string) BatchResult {
+	normalized := normalizeCardNumber(raw)
+	result := BatchResult{Input: raw, Normalized: normalized}
+
+	if len(normalized) < minCardLength || len(normalized) > maxCardLength {
+		return result
+	}
+
+	result.Valid = luhnAlgorithm(normalized)
+	if result.Valid {
+		if rule, ok := classifyBrand(normalized); ok {
+			result.Brand = rule.Name
+			result.Length = len(normalized)
+			result.CVVLength = rule.CVVLength
+		}
+	}
+
+	return result
+}
+
+// runBatchPool validates numbers concurrently with workers goroutines
+// pulling from a shared job queue, preserving input order in the returned
+// slice. Factored out of handleBatchValidate so BenchmarkBatchParallel can
+// exercise the exact pooling logic the handler uses.
+func runBatchPool(numbers []string, workers int) []BatchResult {
+	results := make([]BatchResult, len(numbers))
+	jobs := make(chan int)
+
+	if workers > len(numbers) {
+		workers = len(numbers)
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results[i] = validateOne(numbers[i])
+			}
+		}()
+	}
+
+	for i := range numbers {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return results
+}
+
+// runBatchSequential validates numbers one at a time on the calling
+// goroutine; it exists as the baseline BenchmarkBatchSequential compares
+// runBatchPool against.
+func runBatchSequential(numbers []string) []BatchResult {
+	results := make([]BatchResult, len(numbers))
+	for i, n := range numbers {
+		results[i] = validateOne(n)
+	}
+	return results
+}
+
+// handleBatchValidate validates up to maxBatchSize card numbers
+// concurrently using a bounded worker pool (sized by batchWorkerCount),
+// preserving input order in the response.
+func (s *Server) handleBatchValidate(writer http.ResponseWriter, request *http.Request) {
+	if request.Method != http.MethodPost {
+		writeError(writer, http.StatusMethodNotAllowed, "invalid request method")
+		return
+	}
+
+	var req BatchRequest
+	if err := decodeAndValidate(writer, request, &req); err != nil {
+		return
+	}
+
+	// Belt-and-braces: the "max=1000" validate tag on BatchRequest.Numbers
+	// must stay in sync with maxBatchSize; enforce the constant directly
+	// too so the two can't silently drift apart.
+	if len(req.Numbers) > maxBatchSize {
+		writeError(writer, http.StatusUnprocessableEntity, "numbers must contain at most 1000 entries")
+		return
+	}
+
+	results := runBatchPool(req.Numbers, batchWorkerCount())
+
+	jsonResponse, err := json.Marshal(BatchResponse{Results: results})
+	if err != nil {
+		writeError(writer, http.StatusInternalServerError, "error creating response")
+		return
+	}
+
+	writer.Header().Set("Content-Type", "application/json")
+	writer.Write(jsonResponse)
+}
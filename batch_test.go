@@ -0,0 +1,32 @@
+// batch_test.go
+package main
+
+import "testing"
+
+// benchmarkNumbers builds n normalized card numbers (mixing Visa and
+// MasterCard PANs) for the batch benchmarks below.
+func benchmarkNumbers(n int) []string {
+	sample := []string{"4111111111111111", "5500000000000004"}
+	numbers := make([]string, n)
+	for i := range numbers {
+		numbers[i] = sample[i%len(sample)]
+	}
+	return numbers
+}
+
+func BenchmarkBatchSequential(b *testing.B) {
+	numbers := benchmarkNumbers(1000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		runBatchSequential(numbers)
+	}
+}
+
+func BenchmarkBatchParallel(b *testing.B) {
+	numbers := benchmarkNumbers(1000)
+	workers := batchWorkerCount()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		runBatchPool(numbers, workers)
+	}
+}
@@ -0,0 +1,211 @@
+// brands.go
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+)
+
+// prefixRange is an inclusive range of BIN/IIN prefixes sharing a fixed
+// digit width, e.g. {Width: 2, Low: 51, High: 55} for MasterCard's "5"
+// series.
+type prefixRange struct {
+	Width     int
+	Low, High int
+}
+
+// brandRule describes how to recognize, and generate, a single card brand:
+// the BIN/IIN prefix ranges that identify it, the PAN lengths it accepts,
+// and its CVV length.
+type brandRule struct {
+	Name      string
+	Prefixes  []prefixRange
+	Lengths   []int
+	CVVLength int
+}
+
+// matches reports whether number starts with one of rule's prefix ranges.
+func (rule brandRule) matches(number string) bool {
+	for _, p := range rule.Prefixes {
+		if prefixInRange(number, p.Width, p.Low, p.High) {
+			return true
+		}
+	}
+	return false
+}
+
+// prefixInRange reports whether the first width digits of number, read as
+// an integer, fall within [low, high].
+func prefixInRange(number string, width, low, high int) bool {
+	if len(number) < width {
+		return false
+	}
+	prefix, err := strconv.Atoi(number[:width])
+	if err != nil {
+		return false
+	}
+	return prefix >= low && prefix <= high
+}
+
+// brandRules lists the supported brands in the order they should be
+// checked; more specific rules (Amex, Diners, JCB, Discover) come before
+// the broader MasterCard/Maestro ranges they would otherwise overlap with.
+var brandRules = []brandRule{
+	{
+		Name:      "amex",
+		Lengths:   []int{15},
+		CVVLength: 4,
+		Prefixes: []prefixRange{
+			{Width: 2, Low: 34, High: 34},
+			{Width: 2, Low: 37, High: 37},
+		},
+	},
+	{
+		Name:      "diners",
+		Lengths:   []int{14},
+		CVVLength: 3,
+		Prefixes: []prefixRange{
+			{Width: 3, Low: 300, High: 305},
+			{Width: 2, Low: 36, High: 36},
+			{Width: 2, Low: 38, High: 38},
+		},
+	},
+	{
+		Name:      "jcb",
+		Lengths:   []int{16},
+		CVVLength: 3,
+		Prefixes: []prefixRange{
+			{Width: 4, Low: 3528, High: 3589},
+		},
+	},
+	{
+		// Discover's 622126-622925 co-brand range is deliberately omitted:
+		// it's a subset of UnionPay's broader 62 prefix below, and carrying
+		// it here would make any UnionPay number generated in that band
+		// misclassify as Discover.
+		Name:      "discover",
+		Lengths:   []int{16},
+		CVVLength: 3,
+		Prefixes: []prefixRange{
+			{Width: 4, Low: 6011, High: 6011},
+			{Width: 2, Low: 65, High: 65},
+			{Width: 3, Low: 644, High: 649},
+		},
+	},
+	{
+		Name:      "unionpay",
+		Lengths:   []int{16, 17, 18, 19},
+		CVVLength: 3,
+		Prefixes: []prefixRange{
+			{Width: 2, Low: 62, High: 62},
+		},
+	},
+	{
+		Name:      "maestro",
+		Lengths:   []int{12, 13, 14, 15, 16, 17, 18, 19},
+		CVVLength: 3,
+		Prefixes: []prefixRange{
+			{Width: 2, Low: 50, High: 50},
+			{Width: 2, Low: 56, High: 58},
+			{Width: 2, Low: 67, High: 67},
+		},
+	},
+	{
+		Name:      "mastercard",
+		Lengths:   []int{16},
+		CVVLength: 3,
+		Prefixes: []prefixRange{
+			{Width: 2, Low: 51, High: 55},
+			{Width: 4, Low: 2221, High: 2720},
+		},
+	},
+	{
+		Name:      "visa",
+		Lengths:   []int{13, 16, 19},
+		CVVLength: 3,
+		Prefixes: []prefixRange{
+			{Width: 1, Low: 4, High: 4},
+		},
+	},
+}
+
+// brandValidators maps each supported brand name to a function that reports
+// whether number matches that brand's prefix and length rules, returning
+// the matched brand name for convenience.
+var brandValidators = buildBrandValidators()
+
+func buildBrandValidators() map[string]func(string) (bool, string) {
+	validators := make(map[string]func(string) (bool, string), len(brandRules))
+	for _, rule := range brandRules {
+		rule := rule
+		validators[rule.Name] = func(number string) (bool, string) {
+			if !rule.matches(number) {
+				return false, ""
+			}
+			for _, length := range rule.Lengths {
+				if len(number) == length {
+					return true, rule.Name
+				}
+			}
+			return false, ""
+		}
+	}
+	return validators
+}
+
+// lookupBrand returns the brandRule registered under name, if any.
+func lookupBrand(name string) (brandRule, bool) {
+	for _, rule := range brandRules {
+		if rule.Name == name {
+			return rule, true
+		}
+	}
+	return brandRule{}, false
+}
+
+// BrandInfo describes a supported brand's detection rules for the /brands
+// listing endpoint.
+type BrandInfo struct {
+	Name      string `json:"name"`
+	Lengths   []int  `json:"lengths"`
+	CVVLength int    `json:"cvv_length"`
+}
+
+// handleBrands lists the brands supported by classifyBrand and the PAN and
+// CVV lengths they expect.
+func (s *Server) handleBrands(writer http.ResponseWriter, request *http.Request) {
+	if request.Method != http.MethodGet {
+		writeError(writer, http.StatusMethodNotAllowed, "invalid request method")
+		return
+	}
+
+	brands := make([]BrandInfo, 0, len(brandRules))
+	for _, rule := range brandRules {
+		brands = append(brands, BrandInfo{
+			Name:      rule.Name,
+			Lengths:   rule.Lengths,
+			CVVLength: rule.CVVLength,
+		})
+	}
+
+	jsonResponse, err := json.Marshal(brands)
+	if err != nil {
+		writeError(writer, http.StatusInternalServerError, "error creating response")
+		return
+	}
+
+	writer.Header().Set("Content-Type", "application/json")
+	writer.Write(jsonResponse)
+}
+
+// classifyBrand runs number through brandRules in order and returns the
+// matched rule, or ok=false if no brand recognizes it.
+func classifyBrand(number string) (rule brandRule, ok bool) {
+	for _, r := range brandRules {
+		if matched, _ := brandValidators[r.Name](number); matched {
+			return r, true
+		}
+	}
+	return brandRule{}, false
+}
@@ -0,0 +1,21 @@
+// errors.go
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// errorEnvelope is the JSON shape returned for every non-2xx response under
+// the versioned API.
+type errorEnvelope struct {
+	Error string `json:"error"`
+	Code  int    `json:"code"`
+}
+
+// writeError writes status and message as an errorEnvelope.
+func writeError(writer http.ResponseWriter, status int, message string) {
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(status)
+	json.NewEncoder(writer).Encode(errorEnvelope{Error: message, Code: status})
+}
@@ -0,0 +1,192 @@
+// generate.go
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// maxGenerateCount is the largest number of cards a single /generate
+// request may ask for.
+const maxGenerateCount = 100
+
+// testNumberNotice is attached to every generated or completed number so
+// callers can't mistake it for a real, chargeable card.
+const testNumberNotice = "synthetic test number only, not a real card"
+
+// knownLiveBINRanges lists BIN ranges known to be allocated to real
+// issuers. generateForBrand and handleComplete refuse to emit a number
+// starting with one of these, even when it otherwise satisfies a brand's
+// prefix rules.
+var knownLiveBINRanges = []prefixRange{
+	{Width: 6, Low: 400000, High: 400000},
+}
+
+func isKnownLiveBIN(number string) bool {
+	for _, r := range knownLiveBINRanges {
+		if prefixInRange(number, r.Width, r.Low, r.High) {
+			return true
+		}
+	}
+	return false
+}
+
+// luhnCheckDigit returns the digit that, appended to partial, makes it
+// pass the Luhn checksum.
+func luhnCheckDigit(partial string) (int, error) {
+	sum := 0
+	alternate := true
+	for i := len(partial) - 1; i >= 0; i-- {
+		r := rune(partial[i])
+		if !unicode.IsDigit(r) {
+			return 0, fmt.Errorf("non-digit character in %q", partial)
+		}
+
+		digit := int(r - '0')
+		if alternate {
+			digit *= 2
+			if digit > 9 {
+				digit -= 9
+			}
+		}
+
+		sum += digit
+		alternate = !alternate
+	}
+
+	return (10 - sum%10) % 10, nil
+}
+
+// generateForBrand produces a Luhn-valid test number matching one of
+// rule's prefix ranges and lengths.
+func generateForBrand(rule brandRule) (string, error) {
+	if len(rule.Prefixes) == 0 || len(rule.Lengths) == 0 {
+		return "", fmt.Errorf("brand %q has no generation rules", rule.Name)
+	}
+
+	length := rule.Lengths[rand.Intn(len(rule.Lengths))]
+	selected := rule.Prefixes[rand.Intn(len(rule.Prefixes))]
+	prefix := selected.Low + rand.Intn(selected.High-selected.Low+1)
+
+	body := strconv.Itoa(prefix)
+	for len(body) < length-1 {
+		body += strconv.Itoa(rand.Intn(10))
+	}
+	body = body[:length-1]
+
+	checkDigit, err := luhnCheckDigit(body)
+	if err != nil {
+		return "", err
+	}
+
+	return body + strconv.Itoa(checkDigit), nil
+}
+
+// GenerateRequest is the body accepted by handleGenerate.
+type GenerateRequest struct {
+	Brand string `json:"brand" validate:"required"`
+	Count int    `json:"count" validate:"required,min=1,max=100"`
+}
+
+// GenerateResponse returns the generated test card numbers.
+type GenerateResponse struct {
+	Brand   string   `json:"brand"`
+	Numbers []string `json:"numbers"`
+	Notice  string   `json:"notice"`
+}
+
+// handleGenerate produces Count Luhn-valid test numbers for Brand.
+func (s *Server) handleGenerate(writer http.ResponseWriter, request *http.Request) {
+	if request.Method != http.MethodPost {
+		writeError(writer, http.StatusMethodNotAllowed, "invalid request method")
+		return
+	}
+
+	var req GenerateRequest
+	if err := decodeAndValidate(writer, request, &req); err != nil {
+		return
+	}
+
+	rule, ok := lookupBrand(strings.ToLower(req.Brand))
+	if !ok {
+		writeError(writer, http.StatusUnprocessableEntity, "unknown brand")
+		return
+	}
+
+	if req.Count > maxGenerateCount {
+		writeError(writer, http.StatusUnprocessableEntity, "count exceeds the maximum of 100")
+		return
+	}
+
+	numbers := make([]string, 0, req.Count)
+	for len(numbers) < req.Count {
+		number, err := generateForBrand(rule)
+		if err != nil {
+			writeError(writer, http.StatusInternalServerError, "error generating number")
+			return
+		}
+		if isKnownLiveBIN(number) {
+			continue
+		}
+		numbers = append(numbers, number)
+	}
+
+	jsonResponse, err := json.Marshal(GenerateResponse{Brand: rule.Name, Numbers: numbers, Notice: testNumberNotice})
+	if err != nil {
+		writeError(writer, http.StatusInternalServerError, "error creating response")
+		return
+	}
+
+	writer.Header().Set("Content-Type", "application/json")
+	writer.Write(jsonResponse)
+}
+
+// CompleteRequest is the body accepted by handleComplete.
+type CompleteRequest struct {
+	Number string `json:"number" validate:"required,numeric,min=11,max=18"`
+}
+
+// CompleteResponse returns the Luhn-completed card number.
+type CompleteResponse struct {
+	Number string `json:"number"`
+	Notice string `json:"notice"`
+}
+
+// handleComplete appends the Luhn check digit to a partial card number.
+func (s *Server) handleComplete(writer http.ResponseWriter, request *http.Request) {
+	if request.Method != http.MethodPost {
+		writeError(writer, http.StatusMethodNotAllowed, "invalid request method")
+		return
+	}
+
+	var req CompleteRequest
+	if err := decodeAndValidate(writer, request, &req); err != nil {
+		return
+	}
+
+	checkDigit, err := luhnCheckDigit(req.Number)
+	if err != nil {
+		writeError(writer, http.StatusUnprocessableEntity, "number must contain only digits")
+		return
+	}
+
+	completed := req.Number + strconv.Itoa(checkDigit)
+	if isKnownLiveBIN(completed) {
+		writeError(writer, http.StatusUnprocessableEntity, "completed number matches a reserved live BIN range")
+		return
+	}
+
+	jsonResponse, err := json.Marshal(CompleteResponse{Number: completed, Notice: testNumberNotice})
+	if err != nil {
+		writeError(writer, http.StatusInternalServerError, "error creating response")
+		return
+	}
+
+	writer.Header().Set("Content-Type", "application/json")
+	writer.Write(jsonResponse)
+}
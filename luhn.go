@@ -0,0 +1,32 @@
+// luhn.go
+package main
+
+import "unicode"
+
+// luhnAlgorithm reports whether number passes the Luhn checksum used to
+// validate credit card numbers. Any non-digit characters cause it to
+// return false.
+func luhnAlgorithm(number string) bool {
+	sum := 0
+	alternate := false
+
+	for i := len(number) - 1; i >= 0; i-- {
+		r := rune(number[i])
+		if !unicode.IsDigit(r) {
+			return false
+		}
+
+		digit := int(r - '0')
+		if alternate {
+			digit *= 2
+			if digit > 9 {
+				digit -= 9
+			}
+		}
+
+		sum += digit
+		alternate = !alternate
+	}
+
+	return len(number) > 0 && sum%10 == 0
+}
@@ -0,0 +1,117 @@
+// server.go
+package main
+
+import (
+	"log"
+	"net/http"
+	"os"
+
+	"golang.org/x/time/rate"
+)
+
+// Config holds a Server's runtime configuration.
+type Config struct {
+	Addr           string
+	RateLimitRPS   float64
+	RateLimitBurst int
+}
+
+// DefaultConfig returns the Config used by main, with values overridable
+// via the ADDR environment variable.
+func DefaultConfig() Config {
+	return Config{
+		Addr:           envOrDefault("ADDR", ":8080"),
+		RateLimitRPS:   10,
+		RateLimitBurst: 20,
+	}
+}
+
+func envOrDefault(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
+
+// Server holds the dependencies shared by the API's handlers: a logger, the
+// resolved configuration, and a global rate limiter.
+type Server struct {
+	logger  *log.Logger
+	config  Config
+	limiter *rate.Limiter
+}
+
+// Init constructs a Server from config, wiring up its logger and rate
+// limiter.
+func Init(config Config) (*Server, error) {
+	return &Server{
+		logger:  log.New(os.Stdout, "credit-card-validator: ", log.LstdFlags),
+		config:  config,
+		limiter: rate.NewLimiter(rate.Limit(config.RateLimitRPS), config.RateLimitBurst),
+	}, nil
+}
+
+// Routes builds the http.Handler serving the full API: the versioned
+// surface under /api/v1, a 404 handler for unrecognized /api/ paths, and a
+// deprecation warning on the legacy root route.
+func (s *Server) Routes() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/api/v1/validate", s.withRateLimit(s.handleValidate))
+	mux.HandleFunc("/api/v1/validate/batch", s.withRateLimit(s.handleBatchValidate))
+	mux.HandleFunc("/api/v1/brands", s.withRateLimit(s.handleBrands))
+	mux.HandleFunc("/api/v1/generate", s.withRateLimit(s.handleGenerate))
+	mux.HandleFunc("/api/v1/complete", s.withRateLimit(s.handleComplete))
+	mux.HandleFunc("/api/", s.handleAPINotFound)
+
+	mux.HandleFunc("/", s.handleLegacyRoot)
+
+	return s.withLogging(mux)
+}
+
+// withRateLimit rejects requests that exceed the server's global rate limit
+// with a 429 error envelope instead of passing them to next.
+func (s *Server) withRateLimit(next http.HandlerFunc) http.HandlerFunc {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		if !s.limiter.Allow() {
+			writeError(writer, http.StatusTooManyRequests, "rate limit exceeded")
+			return
+		}
+		next(writer, request)
+	}
+}
+
+// statusRecorder wraps an http.ResponseWriter to capture the status code a
+// handler wrote, so withLogging can report it after the handler returns.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// withLogging logs each request's method, path, and response status
+// through the server's logger.
+func (s *Server) withLogging(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		recorder := &statusRecorder{ResponseWriter: writer, status: http.StatusOK}
+		next.ServeHTTP(recorder, request)
+		s.logger.Printf("%s %s -> %d", request.Method, request.URL.Path, recorder.status)
+	})
+}
+
+// handleAPINotFound answers any /api/ request that didn't match a
+// registered route.
+func (s *Server) handleAPINotFound(writer http.ResponseWriter, request *http.Request) {
+	writeError(writer, http.StatusNotFound, "unknown endpoint")
+}
+
+// handleLegacyRoot serves the pre-v1 behavior at "/" while warning callers
+// to move to /api/v1/validate.
+func (s *Server) handleLegacyRoot(writer http.ResponseWriter, request *http.Request) {
+	writer.Header().Set("Warning", `299 - "deprecated, use /api/v1/validate"`)
+	s.handleValidate(writer, request)
+}
@@ -0,0 +1,133 @@
+// validate.go
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// ValidateRequest is the typed body accepted by the validation endpoints.
+// Struct tags drive both JSON decoding and field validation.
+type ValidateRequest struct {
+	Number   string `json:"number" validate:"required,numeric,min=12,max=19"`
+	ExpMonth int    `json:"exp_month,omitempty" validate:"omitempty,min=1,max=12"`
+	ExpYear  int    `json:"exp_year,omitempty" validate:"omitempty,gte=2024"`
+	CVV      string `json:"cvv,omitempty" validate:"omitempty,numeric,len=3|len=4"`
+}
+
+// fieldError reports a single failed validation rule.
+type fieldError struct {
+	Field string `json:"field"`
+	Rule  string `json:"rule"`
+}
+
+// validationErrorResponse is the JSON body returned for a 422 response.
+type validationErrorResponse struct {
+	errorEnvelope
+	Errors []fieldError `json:"errors"`
+}
+
+// validate is shared across handlers; validator.Validate is safe for
+// concurrent use once configured.
+var validate = newValidator()
+
+// newValidator builds a validator.Validate that reports JSON field names
+// (e.g. "exp_month") instead of Go struct field names in error output.
+func newValidator() *validator.Validate {
+	v := validator.New()
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+		if name == "-" {
+			return ""
+		}
+		return name
+	})
+	return v
+}
+
+// decodeAndValidate decodes the request body into dst and runs it through
+// validate. On failure it writes the appropriate error envelope to writer
+// (400 for malformed JSON, 422 for failed rules) and returns a non-nil
+// error so the caller can stop handling the request.
+func decodeAndValidate(writer http.ResponseWriter, request *http.Request, dst interface{}) error {
+	if err := json.NewDecoder(request.Body).Decode(dst); err != nil {
+		writeError(writer, http.StatusBadRequest, "invalid JSON payload")
+		return err
+	}
+
+	if err := validate.Struct(dst); err != nil {
+		writeValidationErrors(writer, err)
+		return err
+	}
+
+	return nil
+}
+
+// writeValidationErrors writes a 422 response listing each field that
+// failed validation and the rule it failed, nested under the standard
+// error envelope.
+func writeValidationErrors(writer http.ResponseWriter, err error) {
+	validationErrors, ok := err.(validator.ValidationErrors)
+	if !ok {
+		writeError(writer, http.StatusUnprocessableEntity, "invalid request")
+		return
+	}
+
+	errs := make([]fieldError, 0, len(validationErrors))
+	for _, fe := range validationErrors {
+		errs = append(errs, fieldError{Field: fe.Field(), Rule: fe.Tag()})
+	}
+
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(http.StatusUnprocessableEntity)
+	json.NewEncoder(writer).Encode(validationErrorResponse{
+		errorEnvelope: errorEnvelope{Error: "validation failed", Code: http.StatusUnprocessableEntity},
+		Errors:        errs,
+	})
+}
+
+// Response is the JSON body returned by handleValidate.
+type Response struct {
+	Valid     bool   `json:"valid"`                // Valid indicates whether the card number is valid.
+	Brand     string `json:"brand,omitempty"`      // Brand is the detected card brand, e.g. "visa".
+	Length    int    `json:"length,omitempty"`     // Length is the expected PAN length for Brand.
+	CVVLength int    `json:"cvv_length,omitempty"` // CVVLength is the expected CVV length for Brand.
+}
+
+// handleValidate decodes and validates a single card number and reports
+// whether it passes the Luhn check, along with its detected brand.
+func (s *Server) handleValidate(writer http.ResponseWriter, request *http.Request) {
+	if request.Method != http.MethodPost {
+		writeError(writer, http.StatusMethodNotAllowed, "invalid request method")
+		return
+	}
+
+	var req ValidateRequest
+	if err := decodeAndValidate(writer, request, &req); err != nil {
+		return
+	}
+
+	isValid := luhnAlgorithm(req.Number)
+	response := Response{Valid: isValid}
+
+	if isValid {
+		if rule, ok := classifyBrand(req.Number); ok {
+			response.Brand = rule.Name
+			response.Length = len(req.Number)
+			response.CVVLength = rule.CVVLength
+		}
+	}
+
+	jsonResponse, err := json.Marshal(response)
+	if err != nil {
+		writeError(writer, http.StatusInternalServerError, "error creating response")
+		return
+	}
+
+	writer.Header().Set("Content-Type", "application/json")
+	writer.Write(jsonResponse)
+}